@@ -0,0 +1,64 @@
+package fpath
+
+import (
+	"io"
+	"os"
+	"time"
+)
+
+// File is the subset of *os.File that a Backend must be able to hand back
+// from Open/Create. *os.File satisfies it directly.
+type File interface {
+	io.Reader
+	io.Writer
+	io.Closer
+	io.Seeker
+	Name() string
+	Stat() (os.FileInfo, error)
+}
+
+// Backend abstracts the filesystem operations Path relies on, modeled on
+// io/fs.FS and afero.Fs. It lets Path run against the real OS, an in-memory
+// tree (for tests), a chrooted subtree, or a future remote implementation.
+type Backend interface {
+	Open(name string, flag int, perm os.FileMode) (File, error)
+	Create(name string) (File, error)
+	Mkdir(name string, perm os.FileMode) error
+	MkdirAll(name string, perm os.FileMode) error
+	Remove(name string) error
+	RemoveAll(name string) error
+	Stat(name string) (os.FileInfo, error)
+	Lstat(name string) (os.FileInfo, error)
+	Readlink(name string) (string, error)
+	ReadDir(name string) ([]os.DirEntry, error)
+	Rename(oldname, newname string) error
+	Chmod(name string, mode os.FileMode) error
+	Chtimes(name string, atime, mtime time.Time) error
+}
+
+// PathResolver is implemented by backends that map a path to a real OS file
+// path, and therefore support OS-level primitives like Path.Lock that have no
+// meaning against a virtual filesystem (e.g. MemBackend).
+type PathResolver interface {
+	ResolvePath(name string) (string, error)
+}
+
+// Default is the backend used by paths that have not been bound to one via
+// WithBackend.
+var Default Backend = NewOSBackend()
+
+// WithBackend returns a copy of the path bound to backend b. Derived paths
+// (Join, Parent, ...) inherit the same backend.
+func (p *Path) WithBackend(b Backend) *Path {
+	np := *p
+	np.backend = b
+	return &np
+}
+
+// backendOrDefault returns the backend bound to p, falling back to Default.
+func (p *Path) backendOrDefault() Backend {
+	if p.backend != nil {
+		return p.backend
+	}
+	return Default
+}
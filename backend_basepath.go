@@ -0,0 +1,155 @@
+package fpath
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// BasePathBackend rewrites every path through a fixed root before delegating
+// to an inner Backend, similar to a chroot. Paths that would escape the root
+// (e.g. via "..") are rejected after cleaning.
+type BasePathBackend struct {
+	Root  string
+	Inner Backend
+}
+
+// NewBasePathBackend returns a Backend confined to root, delegating actual
+// I/O to inner.
+func NewBasePathBackend(root string, inner Backend) *BasePathBackend {
+	return &BasePathBackend{Root: filepath.Clean(root), Inner: inner}
+}
+
+func (b *BasePathBackend) resolve(name string) (string, error) {
+	rel := filepath.Clean(strings.TrimPrefix(name, "/"))
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("fpath: path %q escapes base %q", name, b.Root)
+	}
+	return filepath.Join(b.Root, rel), nil
+}
+
+// ResolvePath resolves name against Root and, if Inner also supports it,
+// through Inner as well. It fails if Inner does not support path resolution
+// (e.g. it's a MemBackend), so callers like Path.Lock reject it explicitly
+// instead of silently escaping the chroot.
+func (b *BasePathBackend) ResolvePath(name string) (string, error) {
+	resolved, err := b.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	inner, ok := b.Inner.(PathResolver)
+	if !ok {
+		return "", fmt.Errorf("fpath: inner backend %T of BasePathBackend does not support ResolvePath", b.Inner)
+	}
+	return inner.ResolvePath(resolved)
+}
+
+func (b *BasePathBackend) Open(name string, flag int, perm os.FileMode) (File, error) {
+	p, err := b.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return b.Inner.Open(p, flag, perm)
+}
+
+func (b *BasePathBackend) Create(name string) (File, error) {
+	p, err := b.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return b.Inner.Create(p)
+}
+
+func (b *BasePathBackend) Mkdir(name string, perm os.FileMode) error {
+	p, err := b.resolve(name)
+	if err != nil {
+		return err
+	}
+	return b.Inner.Mkdir(p, perm)
+}
+
+func (b *BasePathBackend) MkdirAll(name string, perm os.FileMode) error {
+	p, err := b.resolve(name)
+	if err != nil {
+		return err
+	}
+	return b.Inner.MkdirAll(p, perm)
+}
+
+func (b *BasePathBackend) Remove(name string) error {
+	p, err := b.resolve(name)
+	if err != nil {
+		return err
+	}
+	return b.Inner.Remove(p)
+}
+
+func (b *BasePathBackend) RemoveAll(name string) error {
+	p, err := b.resolve(name)
+	if err != nil {
+		return err
+	}
+	return b.Inner.RemoveAll(p)
+}
+
+func (b *BasePathBackend) Stat(name string) (os.FileInfo, error) {
+	p, err := b.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return b.Inner.Stat(p)
+}
+
+func (b *BasePathBackend) Lstat(name string) (os.FileInfo, error) {
+	p, err := b.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return b.Inner.Lstat(p)
+}
+
+func (b *BasePathBackend) Readlink(name string) (string, error) {
+	p, err := b.resolve(name)
+	if err != nil {
+		return "", err
+	}
+	return b.Inner.Readlink(p)
+}
+
+func (b *BasePathBackend) ReadDir(name string) ([]os.DirEntry, error) {
+	p, err := b.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	return b.Inner.ReadDir(p)
+}
+
+func (b *BasePathBackend) Rename(oldname, newname string) error {
+	oldp, err := b.resolve(oldname)
+	if err != nil {
+		return err
+	}
+	newp, err := b.resolve(newname)
+	if err != nil {
+		return err
+	}
+	return b.Inner.Rename(oldp, newp)
+}
+
+func (b *BasePathBackend) Chmod(name string, mode os.FileMode) error {
+	p, err := b.resolve(name)
+	if err != nil {
+		return err
+	}
+	return b.Inner.Chmod(p, mode)
+}
+
+func (b *BasePathBackend) Chtimes(name string, atime, mtime time.Time) error {
+	p, err := b.resolve(name)
+	if err != nil {
+		return err
+	}
+	return b.Inner.Chtimes(p, atime, mtime)
+}
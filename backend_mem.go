@@ -0,0 +1,317 @@
+package fpath
+
+import (
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemBackend is an in-memory Backend, useful for unit-testing code that uses
+// Path without touching the real disk. The zero value is not usable; create
+// one with NewMemBackend.
+type MemBackend struct {
+	mu    sync.Mutex
+	nodes map[string]*memNode
+}
+
+type memNode struct {
+	data    []byte
+	mode    os.FileMode
+	modTime time.Time
+	isDir   bool
+}
+
+// NewMemBackend returns an empty in-memory Backend rooted at "/".
+func NewMemBackend() *MemBackend {
+	b := &MemBackend{nodes: map[string]*memNode{}}
+	b.nodes["/"] = &memNode{isDir: true, mode: os.ModeDir | 0755, modTime: time.Time{}}
+	return b
+}
+
+func memClean(name string) string {
+	name = path.Clean("/" + strings.TrimPrefix(name, "/"))
+	return name
+}
+
+func (b *MemBackend) lookup(name string) (*memNode, bool) {
+	n, ok := b.nodes[memClean(name)]
+	return n, ok
+}
+
+func (b *MemBackend) parentDir(name string) (*memNode, error) {
+	dir := path.Dir(memClean(name))
+	n, ok := b.lookup(dir)
+	if !ok || !n.isDir {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return n, nil
+}
+
+func (b *MemBackend) Open(name string, flag int, perm os.FileMode) (File, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clean := memClean(name)
+	n, ok := b.lookup(clean)
+	if !ok {
+		if flag&os.O_CREATE == 0 {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		if _, err := b.parentDir(clean); err != nil {
+			return nil, err
+		}
+		n = &memNode{mode: perm, modTime: time.Time{}}
+		b.nodes[clean] = n
+	}
+	if n.isDir {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrInvalid}
+	}
+	if flag&os.O_TRUNC != 0 {
+		n.data = nil
+	}
+	f := &memFile{name: clean, node: n, backend: b, appendMode: flag&os.O_APPEND != 0}
+	if flag&os.O_APPEND != 0 {
+		f.offset = int64(len(n.data))
+	}
+	return f, nil
+}
+
+func (b *MemBackend) Create(name string) (File, error) {
+	return b.Open(name, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+}
+
+func (b *MemBackend) Mkdir(name string, perm os.FileMode) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clean := memClean(name)
+	if _, ok := b.lookup(clean); ok {
+		return &os.PathError{Op: "mkdir", Path: name, Err: os.ErrExist}
+	}
+	if _, err := b.parentDir(clean); err != nil {
+		return err
+	}
+	b.nodes[clean] = &memNode{isDir: true, mode: perm | os.ModeDir, modTime: time.Time{}}
+	return nil
+}
+
+func (b *MemBackend) MkdirAll(name string, perm os.FileMode) error {
+	clean := memClean(name)
+	parts := strings.Split(strings.Trim(clean, "/"), "/")
+	cur := ""
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+		cur += "/" + part
+		if err := b.Mkdir(cur, perm); err != nil && !os.IsExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *MemBackend) Remove(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clean := memClean(name)
+	n, ok := b.lookup(clean)
+	if !ok {
+		return &os.PathError{Op: "remove", Path: name, Err: os.ErrNotExist}
+	}
+	if n.isDir {
+		prefix := clean + "/"
+		for p := range b.nodes {
+			if strings.HasPrefix(p, prefix) {
+				return &os.PathError{Op: "remove", Path: name, Err: os.ErrExist}
+			}
+		}
+	}
+	delete(b.nodes, clean)
+	return nil
+}
+
+func (b *MemBackend) RemoveAll(name string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clean := memClean(name)
+	prefix := clean + "/"
+	for p := range b.nodes {
+		if p == clean || strings.HasPrefix(p, prefix) {
+			delete(b.nodes, p)
+		}
+	}
+	return nil
+}
+
+func (b *MemBackend) Stat(name string) (os.FileInfo, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n, ok := b.lookup(name)
+	if !ok {
+		return nil, &os.PathError{Op: "stat", Path: name, Err: os.ErrNotExist}
+	}
+	return memFileInfo{name: path.Base(memClean(name)), node: n}, nil
+}
+
+func (b *MemBackend) Lstat(name string) (os.FileInfo, error) {
+	return b.Stat(name)
+}
+
+func (b *MemBackend) Readlink(name string) (string, error) {
+	return "", &os.PathError{Op: "readlink", Path: name, Err: os.ErrInvalid}
+}
+
+func (b *MemBackend) ReadDir(name string) ([]os.DirEntry, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	clean := memClean(name)
+	n, ok := b.lookup(clean)
+	if !ok || !n.isDir {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	prefix := clean
+	if prefix != "/" {
+		prefix += "/"
+	}
+	seen := map[string]bool{}
+	entries := make([]os.DirEntry, 0)
+	for p, child := range b.nodes {
+		if p == clean || !strings.HasPrefix(p, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(p, prefix)
+		if strings.Contains(rest, "/") {
+			continue
+		}
+		if seen[rest] {
+			continue
+		}
+		seen[rest] = true
+		entries = append(entries, memDirEntry{memFileInfo{name: rest, node: child}})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+func (b *MemBackend) Rename(oldname, newname string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	oldClean, newClean := memClean(oldname), memClean(newname)
+	n, ok := b.nodes[oldClean]
+	if !ok {
+		return &os.PathError{Op: "rename", Path: oldname, Err: os.ErrNotExist}
+	}
+	if _, err := b.parentDir(newClean); err != nil {
+		return err
+	}
+	delete(b.nodes, oldClean)
+	b.nodes[newClean] = n
+	return nil
+}
+
+func (b *MemBackend) Chmod(name string, mode os.FileMode) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n, ok := b.lookup(name)
+	if !ok {
+		return &os.PathError{Op: "chmod", Path: name, Err: os.ErrNotExist}
+	}
+	n.mode = mode
+	return nil
+}
+
+func (b *MemBackend) Chtimes(name string, atime, mtime time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n, ok := b.lookup(name)
+	if !ok {
+		return &os.PathError{Op: "chtimes", Path: name, Err: os.ErrNotExist}
+	}
+	n.modTime = mtime
+	return nil
+}
+
+type memFile struct {
+	name       string
+	node       *memNode
+	backend    *MemBackend
+	offset     int64
+	appendMode bool
+}
+
+func (f *memFile) Read(p []byte) (int, error) {
+	f.backend.mu.Lock()
+	defer f.backend.mu.Unlock()
+	if f.offset >= int64(len(f.node.data)) {
+		return 0, io.EOF
+	}
+	n := copy(p, f.node.data[f.offset:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+func (f *memFile) Write(p []byte) (int, error) {
+	f.backend.mu.Lock()
+	defer f.backend.mu.Unlock()
+	if f.appendMode {
+		f.offset = int64(len(f.node.data))
+	}
+	end := f.offset + int64(len(p))
+	if end > int64(len(f.node.data)) {
+		grown := make([]byte, end)
+		copy(grown, f.node.data)
+		f.node.data = grown
+	}
+	n := copy(f.node.data[f.offset:end], p)
+	f.offset += int64(n)
+	f.node.modTime = time.Now()
+	return n, nil
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+func (f *memFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case 0:
+		f.offset = offset
+	case 1:
+		f.offset += offset
+	case 2:
+		f.offset = int64(len(f.node.data)) + offset
+	}
+	return f.offset, nil
+}
+
+func (f *memFile) Name() string {
+	return f.name
+}
+
+func (f *memFile) Stat() (os.FileInfo, error) {
+	return memFileInfo{name: path.Base(f.name), node: f.node}, nil
+}
+
+type memFileInfo struct {
+	name string
+	node *memNode
+}
+
+func (fi memFileInfo) Name() string       { return fi.name }
+func (fi memFileInfo) Size() int64        { return int64(len(fi.node.data)) }
+func (fi memFileInfo) Mode() os.FileMode  { return fi.node.mode }
+func (fi memFileInfo) ModTime() time.Time { return fi.node.modTime }
+func (fi memFileInfo) IsDir() bool        { return fi.node.isDir }
+func (fi memFileInfo) Sys() interface{}   { return nil }
+
+type memDirEntry struct {
+	info memFileInfo
+}
+
+func (e memDirEntry) Name() string               { return e.info.Name() }
+func (e memDirEntry) IsDir() bool                { return e.info.IsDir() }
+func (e memDirEntry) Type() os.FileMode          { return e.info.Mode().Type() }
+func (e memDirEntry) Info() (os.FileInfo, error) { return e.info, nil }
@@ -0,0 +1,72 @@
+package fpath
+
+import (
+	"os"
+	"time"
+)
+
+// OSBackend implements Backend directly against the local filesystem. It is
+// the default backend and preserves the package's historical behavior.
+type OSBackend struct{}
+
+// NewOSBackend returns a Backend backed by the real filesystem.
+func NewOSBackend() *OSBackend {
+	return &OSBackend{}
+}
+
+// ResolvePath returns name unchanged: OSBackend paths are already real OS paths.
+func (*OSBackend) ResolvePath(name string) (string, error) {
+	return name, nil
+}
+
+func (*OSBackend) Open(name string, flag int, perm os.FileMode) (File, error) {
+	return os.OpenFile(name, flag, perm)
+}
+
+func (*OSBackend) Create(name string) (File, error) {
+	return os.Create(name)
+}
+
+func (*OSBackend) Mkdir(name string, perm os.FileMode) error {
+	return os.Mkdir(name, perm)
+}
+
+func (*OSBackend) MkdirAll(name string, perm os.FileMode) error {
+	return os.MkdirAll(name, perm)
+}
+
+func (*OSBackend) Remove(name string) error {
+	return os.Remove(name)
+}
+
+func (*OSBackend) RemoveAll(name string) error {
+	return os.RemoveAll(name)
+}
+
+func (*OSBackend) Stat(name string) (os.FileInfo, error) {
+	return os.Stat(name)
+}
+
+func (*OSBackend) Lstat(name string) (os.FileInfo, error) {
+	return os.Lstat(name)
+}
+
+func (*OSBackend) Readlink(name string) (string, error) {
+	return os.Readlink(name)
+}
+
+func (*OSBackend) ReadDir(name string) ([]os.DirEntry, error) {
+	return os.ReadDir(name)
+}
+
+func (*OSBackend) Rename(oldname, newname string) error {
+	return os.Rename(oldname, newname)
+}
+
+func (*OSBackend) Chmod(name string, mode os.FileMode) error {
+	return os.Chmod(name, mode)
+}
+
+func (*OSBackend) Chtimes(name string, atime, mtime time.Time) error {
+	return os.Chtimes(name, atime, mtime)
+}
@@ -0,0 +1,112 @@
+package fpath
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemBackend_RoundTrip(t *testing.T) {
+	be := NewMemBackend()
+	p := New("/dir/file.txt").WithBackend(be)
+
+	if err := p.Parent().MkDir(0755, true); err != nil {
+		t.Fatalf("MkDir() error = %v", err)
+	}
+	if err := p.WriteBytes([]byte("hello")); err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+	if got := string(p.ReadBytes()); got != "hello" {
+		t.Errorf("ReadBytes() = %q, want %q", got, "hello")
+	}
+	if !p.Exists() {
+		t.Error("Exists() = false, want true")
+	}
+	if p.Size() != 5 {
+		t.Errorf("Size() = %d, want 5", p.Size())
+	}
+
+	entries := p.Parent().ListDir(true)
+	if len(entries) != 1 || entries[0].Base() != "file.txt" {
+		t.Errorf("ListDir() = %v, want [file.txt]", entries)
+	}
+
+	if err := p.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if p.Exists() {
+		t.Error("Exists() = true after Remove, want false")
+	}
+}
+
+func TestBasePathBackend_RejectsEscape(t *testing.T) {
+	be := NewBasePathBackend(t.TempDir(), NewOSBackend())
+	p := New("../escape.txt").WithBackend(be)
+
+	if err := p.Touch(); err == nil {
+		t.Error("Touch() with an escaping path succeeded, want error")
+	}
+}
+
+func TestBasePathBackend_ConfinesWrites(t *testing.T) {
+	root := t.TempDir()
+	be := NewBasePathBackend(root, NewOSBackend())
+	p := New("/inside.txt").WithBackend(be)
+
+	if err := p.WriteBytes([]byte("ok")); err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+	real := New(root).Join("inside.txt")
+	if got := string(real.ReadBytes()); got != "ok" {
+		t.Errorf("file not written inside confined root, got %q", got)
+	}
+}
+
+func TestBasePathBackend_RejectsNestedEscape(t *testing.T) {
+	be := NewBasePathBackend(t.TempDir(), NewOSBackend())
+	p := New("a/../../escape.txt").WithBackend(be)
+
+	if err := p.Touch(); err == nil {
+		t.Error("Touch() with a nested escaping path succeeded, want error")
+	}
+}
+
+func TestMemBackend_WriteSetsModTime(t *testing.T) {
+	be := NewMemBackend()
+	p := New("/file.txt").WithBackend(be)
+
+	if err := p.WriteBytes([]byte("v1")); err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+	if got := p.Stat().ModTime(); got.IsZero() {
+		t.Error("ModTime() is zero right after a write, want a real timestamp")
+	}
+}
+
+func TestSyncTo_SkipUnchanged_DetectsChangedSameSizeFile(t *testing.T) {
+	be := NewMemBackend()
+	src := New("/src").WithBackend(be)
+	dst := New("/dst").WithBackend(be)
+	if err := src.MkDir(0755, true); err != nil {
+		t.Fatalf("MkDir() error = %v", err)
+	}
+	if err := src.Join("a.txt").WriteBytes([]byte("aaaa")); err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+
+	opts := SyncOptions{SkipUnchanged: true, Copy: CopyOptions{PreserveTimes: true}}
+	if err := src.SyncTo(dst, opts); err != nil {
+		t.Fatalf("initial SyncTo() error = %v", err)
+	}
+
+	time.Sleep(time.Millisecond)
+	if err := src.Join("a.txt").WriteBytes([]byte("bbbb")); err != nil {
+		t.Fatalf("WriteBytes() (change) error = %v", err)
+	}
+	if err := src.SyncTo(dst, opts); err != nil {
+		t.Fatalf("second SyncTo() error = %v", err)
+	}
+
+	if got := string(dst.Join("a.txt").ReadBytes()); got != "bbbb" {
+		t.Errorf("dst/a.txt = %q, want %q (SkipUnchanged incorrectly skipped a changed same-size file)", got, "bbbb")
+	}
+}
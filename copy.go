@@ -0,0 +1,101 @@
+package fpath
+
+import (
+	"fmt"
+	"io"
+)
+
+// CopyOptions configures Path.CopyTo and Path.MoveTo.
+type CopyOptions struct {
+	Overwrite     bool
+	PreservePerm  bool
+	PreserveTimes bool
+	BufferSize    int
+	Progress      func(written, total int64)
+}
+
+// CopyTo copies p's content to dst through both paths' backends, so it works
+// over in-memory or chrooted filesystems too, returning the number of bytes
+// written.
+func (p *Path) CopyTo(dst *Path, opts CopyOptions) (int64, error) {
+	if dst.Exists() && !opts.Overwrite {
+		return 0, fmt.Errorf("fpath: %s already exists", dst.String())
+	}
+
+	srcInfo, err := p.be().Stat(p.path)
+	if err != nil {
+		return 0, err
+	}
+	in, err := p.be().Open(p.path, int(ForReading), 0644)
+	if err != nil {
+		return 0, err
+	}
+	defer in.Close()
+
+	out, err := dst.be().Create(dst.path)
+	if err != nil {
+		return 0, err
+	}
+
+	bufSize := opts.BufferSize
+	if bufSize <= 0 {
+		bufSize = 64 * 1024
+	}
+	buf := make([]byte, bufSize)
+	total := srcInfo.Size()
+	var written int64
+	for {
+		n, rerr := in.Read(buf)
+		if n > 0 {
+			if _, werr := out.Write(buf[:n]); werr != nil {
+				out.Close()
+				return written, werr
+			}
+			written += int64(n)
+			if opts.Progress != nil {
+				opts.Progress(written, total)
+			}
+		}
+		if rerr == io.EOF {
+			break
+		}
+		if rerr != nil {
+			out.Close()
+			return written, rerr
+		}
+	}
+	if err := out.Close(); err != nil {
+		return written, err
+	}
+
+	if opts.PreservePerm {
+		if err := dst.be().Chmod(dst.path, srcInfo.Mode()); err != nil {
+			return written, err
+		}
+	}
+	if opts.PreserveTimes {
+		if err := dst.be().Chtimes(dst.path, srcInfo.ModTime(), srcInfo.ModTime()); err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// MoveTo moves p to dst, trying a backend Rename first and falling back to
+// copy+remove when that fails across filesystem boundaries (EXDEV).
+func (p *Path) MoveTo(dst *Path, opts CopyOptions) error {
+	if dst.Exists() && !opts.Overwrite {
+		return fmt.Errorf("fpath: %s already exists", dst.String())
+	}
+	err := p.be().Rename(p.path, dst.path)
+	if err == nil {
+		return nil
+	}
+	if !isCrossDevice(err) {
+		return err
+	}
+	if _, err := p.CopyTo(dst, opts); err != nil {
+		return err
+	}
+	return p.be().Remove(p.path)
+}
@@ -0,0 +1,133 @@
+package fpath
+
+import (
+	"testing"
+)
+
+func TestPath_CopyTo(t *testing.T) {
+	be := NewMemBackend()
+	src := New("/src.txt").WithBackend(be)
+	dst := New("/dst.txt").WithBackend(be)
+	if err := src.WriteBytes([]byte("payload")); err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+
+	n, err := src.CopyTo(dst, CopyOptions{})
+	if err != nil {
+		t.Fatalf("CopyTo() error = %v", err)
+	}
+	if n != 7 {
+		t.Errorf("CopyTo() wrote %d bytes, want 7", n)
+	}
+	if got := string(dst.ReadBytes()); got != "payload" {
+		t.Errorf("dst content = %q, want %q", got, "payload")
+	}
+}
+
+func TestPath_CopyTo_NoOverwrite(t *testing.T) {
+	be := NewMemBackend()
+	src := New("/src.txt").WithBackend(be)
+	dst := New("/dst.txt").WithBackend(be)
+	if err := src.WriteBytes([]byte("new")); err != nil {
+		t.Fatalf("WriteBytes(src) error = %v", err)
+	}
+	if err := dst.WriteBytes([]byte("old")); err != nil {
+		t.Fatalf("WriteBytes(dst) error = %v", err)
+	}
+
+	if _, err := src.CopyTo(dst, CopyOptions{}); err == nil {
+		t.Error("CopyTo() without Overwrite over an existing file succeeded, want error")
+	}
+	if got := string(dst.ReadBytes()); got != "old" {
+		t.Errorf("dst content = %q, want unchanged %q", got, "old")
+	}
+}
+
+func TestPath_MoveTo(t *testing.T) {
+	be := NewMemBackend()
+	src := New("/src.txt").WithBackend(be)
+	dst := New("/dst.txt").WithBackend(be)
+	if err := src.WriteBytes([]byte("move me")); err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+
+	if err := src.MoveTo(dst, CopyOptions{}); err != nil {
+		t.Fatalf("MoveTo() error = %v", err)
+	}
+	if src.Exists() {
+		t.Error("MoveTo() left the source behind")
+	}
+	if got := string(dst.ReadBytes()); got != "move me" {
+		t.Errorf("dst content = %q, want %q", got, "move me")
+	}
+}
+
+func TestPath_SyncTo(t *testing.T) {
+	be := NewMemBackend()
+	src := New("/src").WithBackend(be)
+	dst := New("/dst").WithBackend(be)
+
+	for _, f := range []string{"a.txt", "sub/b.txt"} {
+		fp := src.Join(f)
+		if err := fp.Parent().MkDir(0755, true); err != nil {
+			t.Fatalf("MkDir(%s) error = %v", fp.Parent(), err)
+		}
+		if err := fp.WriteBytes([]byte(f)); err != nil {
+			t.Fatalf("WriteBytes(%s) error = %v", fp, err)
+		}
+	}
+	if err := dst.MkDir(0755, true); err != nil {
+		t.Fatalf("MkDir(dst) error = %v", err)
+	}
+	if err := dst.Join("stale.txt").WriteBytes([]byte("old")); err != nil {
+		t.Fatalf("WriteBytes(stale) error = %v", err)
+	}
+
+	if err := src.SyncTo(dst, SyncOptions{Delete: true}); err != nil {
+		t.Fatalf("SyncTo() error = %v", err)
+	}
+
+	if got := string(dst.Join("a.txt").ReadBytes()); got != "a.txt" {
+		t.Errorf("dst/a.txt = %q, want %q", got, "a.txt")
+	}
+	if got := string(dst.Join("sub/b.txt").ReadBytes()); got != "sub/b.txt" {
+		t.Errorf("dst/sub/b.txt = %q, want %q", got, "sub/b.txt")
+	}
+	if dst.Join("stale.txt").Exists() {
+		t.Error("SyncTo(Delete: true) left a stale file behind")
+	}
+}
+
+func TestPath_SyncTo_SkipUnchanged(t *testing.T) {
+	be := NewMemBackend()
+	src := New("/src").WithBackend(be)
+	dst := New("/dst").WithBackend(be)
+	if err := src.MkDir(0755, true); err != nil {
+		t.Fatalf("MkDir() error = %v", err)
+	}
+	if err := src.Join("a.txt").WriteBytes([]byte("same")); err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+
+	copies := 0
+	opts := SyncOptions{
+		SkipUnchanged: true,
+		CompareHash:   true,
+		Algo:          MD5,
+		Copy:          CopyOptions{Progress: func(written, total int64) { copies++ }},
+	}
+	if err := src.SyncTo(dst, opts); err != nil {
+		t.Fatalf("initial SyncTo() error = %v", err)
+	}
+	if copies == 0 {
+		t.Fatal("initial SyncTo() did not copy the new file")
+	}
+
+	copies = 0
+	if err := src.SyncTo(dst, opts); err != nil {
+		t.Fatalf("second SyncTo() error = %v", err)
+	}
+	if copies != 0 {
+		t.Errorf("second SyncTo() re-copied an unchanged file (Progress called %d times)", copies)
+	}
+}
@@ -2,8 +2,10 @@ package fpath
 
 import (
 	"bufio"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"os"
@@ -19,12 +21,17 @@ const (
 	ListSeparator = os.PathListSeparator
 )
 
-type Path string
+// Path represents a filesystem path. Operations on it are routed through a
+// Backend (see backend.go), which defaults to the real OS filesystem but can
+// be swapped per-path via WithBackend.
+type Path struct {
+	path    string
+	backend Backend
+}
 
 // New creates a new path
-func New(path string)  *Path{
-	p := Path(path)
-	return &p
+func New(path string) *Path {
+	return &Path{path: path}
 }
 
 // Join returns a new path by joining multiple Path/string elements
@@ -33,12 +40,12 @@ func Join(elem ...string) *Path {
 }
 
 // Expand returns a new path with expanded Environment
-func Expand(path string)  *Path{
+func Expand(path string) *Path {
 	return New(os.ExpandEnv(path))
 }
 
 // Cwd returns current working directory
-func Cwd() *Path{
+func Cwd() *Path {
 	wd, err := os.Getwd()
 	if err != nil {
 		return nil
@@ -47,57 +54,73 @@ func Cwd() *Path{
 }
 
 // FromUrl returns a path with contents initiated from url. Default target is basename of url.
-func FromUrl(url string, target ...string) *Path{
-	if len(target) < 1{
+// If checksum.Want is non-empty, the download is verified against it while it streams to disk
+// (see DownloadFrom), and the output is deleted on mismatch. Pass Checksum{} to skip verification.
+func FromUrl(url string, checksum Checksum, target ...string) *Path {
+	if len(target) < 1 {
 		target = append(target, filepath.Base(url))
 	}
 	p := Expand(target[0])
-	p.DownloadFrom(url)
+	if checksum.Want != "" {
+		p.DownloadFrom(url, checksum)
+	} else {
+		p.DownloadFrom(url)
+	}
 	return p
 }
 
 // String returns path as string
 func (p *Path) String() string {
-	return string(*p)
+	return p.path
+}
+
+// derive returns a new Path for s that inherits p's backend
+func (p *Path) derive(s string) *Path {
+	return &Path{path: s, backend: p.backend}
+}
+
+// be returns the backend bound to p, falling back to Default
+func (p *Path) be() Backend {
+	return p.backendOrDefault()
 }
 
 // Join returns a new path by joining multiple Path/string elements
-func (p *Path) Join(elem ...string)  *Path{
-	return Join(p.String(), path.Join(elem...))
+func (p *Path) Join(elem ...string) *Path {
+	return p.derive(path.Join(append([]string{p.path}, elem...)...))
 }
 
 // Expand returns a new path with expanded Environment
-func (p *Path) Expand()  *Path{
-	return New(os.ExpandEnv(p.String()))
+func (p *Path) Expand() *Path {
+	return p.derive(os.ExpandEnv(p.path))
 }
 
 // Abs returns the absolute path
-func (p *Path) Abs() *Path{
-	path, _ := filepath.Abs(p.String())
-	return New(path)
+func (p *Path) Abs() *Path {
+	abs, _ := filepath.Abs(p.path)
+	return p.derive(abs)
 }
 
 // Parent returns the parent path
-func (p *Path) Parent() *Path{
-	return New(filepath.Dir(p.String()))
+func (p *Path) Parent() *Path {
+	return p.derive(filepath.Dir(p.path))
 }
 
 // Parents returns nth level parent of the path
-func (p *Path) Parents(level int) *Path{
-	path := p.String()
+func (p *Path) Parents(level int) *Path {
+	pth := p.path
 	for ; level >= 0; level-- {
-		path = filepath.Dir(path)
+		pth = filepath.Dir(pth)
 	}
-	return New(path)
+	return p.derive(pth)
 }
 
 // Base returns the basename of path
-func (p *Path) Base() string{
-	return filepath.Base(p.String())
+func (p *Path) Base() string {
+	return filepath.Base(p.path)
 }
 
 // Base returns the stem of basename
-func (p *Path) Stem() string{
+func (p *Path) Stem() string {
 	base := p.Base()
 	if i := strings.LastIndex(base, "."); i > 0 {
 		return base[:i]
@@ -106,28 +129,28 @@ func (p *Path) Stem() string{
 }
 
 // Base returns the extension of path
-func (p *Path) Ext() string{
+func (p *Path) Ext() string {
 	return filepath.Ext(p.Base())
 }
 
 // Base returns the directory of path
-func (p *Path) Dir() string{
-	return filepath.Dir(p.String())
+func (p *Path) Dir() string {
+	return filepath.Dir(p.path)
 }
 
 // Base returns path with new suffix
-func (p *Path) WithSuffix(suffix string) *Path{
+func (p *Path) WithSuffix(suffix string) *Path {
 	return p.Parent().Join(p.Stem() + suffix)
 }
 
 // Base returns path with new prefix
-func (p *Path) WithPrefix(prefix string) *Path{
+func (p *Path) WithPrefix(prefix string) *Path {
 	return p.Parent().Join(prefix + p.Base())
 }
 
 // Stat returns the FileInfo of the path
-func (p *Path) Stat() os.FileInfo{
-	s, err := os.Stat(p.String())
+func (p *Path) Stat() os.FileInfo {
+	s, err := p.be().Stat(p.path)
 	if err != nil {
 		return nil
 	}
@@ -135,78 +158,78 @@ func (p *Path) Stat() os.FileInfo{
 }
 
 // Size returns the file size of the path
-func (p *Path) Size() int64{
-	if s, err := os.Stat(p.String()); err == nil {
-		s.Size()
+func (p *Path) Size() int64 {
+	if s, err := p.be().Stat(p.path); err == nil {
+		return s.Size()
 	}
 	return -1
 }
 
 // PrettySize returns the file size of the path
-func (p *Path) PrettySize() string{
-	if s, err := os.Stat(p.String()); err == nil {
+func (p *Path) PrettySize() string {
+	if s, err := p.be().Stat(p.path); err == nil {
 		return PrettySize(s.Size())
 	}
 	return "0 B"
 }
+
 // Exists checks path is realized
-func (p *Path) Exists() bool{
-	_, err := os.Stat(p.String())
+func (p *Path) Exists() bool {
+	_, err := p.be().Stat(p.path)
 	return err == nil || os.IsExist(err)
 }
 
 // IsDir checks if path is a directory
-func (p *Path) IsDir() bool{
+func (p *Path) IsDir() bool {
 	s := p.Stat()
 	return s != nil && s.IsDir()
 }
 
 // IsFile checks if path is a file
-func (p *Path) IsFile() bool{
+func (p *Path) IsFile() bool {
 	s := p.Stat()
 	return s != nil && !s.IsDir()
 }
 
 // ReadLink reads the a symlink
-func (p *Path) ReadLink() *Path  {
-	src, err := os.Readlink(p.String())
+func (p *Path) ReadLink() *Path {
+	src, err := p.be().Readlink(p.path)
 	if err != nil {
 		return nil
 	}
-	return New(src)
+	return p.derive(src)
 }
 
 // Touch trys to create the path as a file
 func (p *Path) Touch() error {
-	f, err := os.Create(p.String())
-	f.Close()
-	return err
+	f, err := p.be().Create(p.path)
+	if err != nil {
+		return err
+	}
+	return f.Close()
 }
 
 // Remove removes the path. If path is a directory, it must be empty
 func (p *Path) Remove() error {
-	err := os.Remove(p.String())
-	return err
+	return p.be().Remove(p.path)
 }
 
 // RemoveAll removes the path and all children
 func (p *Path) RemoveAll() error {
-	err := os.RemoveAll(p.String())
-	return err
+	return p.be().RemoveAll(p.path)
 }
 
 // MkDir crates a directory optionally with its parents
 func (p *Path) MkDir(perm os.FileMode, parents bool) error {
 	if parents {
-		return os.MkdirAll(p.String(), perm)
-	} else {
-		return os.Mkdir(p.String(), perm)
+		return p.be().MkdirAll(p.path, perm)
 	}
+	return p.be().Mkdir(p.path, perm)
 }
 
 // ReadDir returns all files in the path
-func (p *Path) ReadDir() []os.DirEntry  {
-	files, err := os.ReadDir(p.String())
+func (p *Path) ReadDir() []os.DirEntry {
+	files, err := p.be().ReadDir(p.path)
 	if err != nil {
 		return nil
 	}
@@ -214,28 +237,27 @@ func (p *Path) ReadDir() []os.DirEntry  {
 }
 
 // ListDir returns all files in the path as Paths optionally filtering the hidden
-func (p *Path) ListDir(hidden bool) []Path  {
+func (p *Path) ListDir(hidden bool) []Path {
 	files := p.ReadDir()
-	dir := p.String()
 	paths := make([]Path, 0, len(files))
-	for _, f := range files{
+	for _, f := range files {
 		if !hidden && strings.Index(f.Name(), ".") == 0 {
 			continue
 		}
-		paths = append(paths, *Join(dir, f.Name()))
+		paths = append(paths, *p.Join(f.Name()))
 	}
 	return paths
 }
 
 // Glob returns all files matching pattern in the path as Paths
-func (p *Path) Glob(pattern string) []Path  {
-	files, err := filepath.Glob(p.Join(pattern).String())
+func (p *Path) Glob(pattern string) []Path {
+	matches, err := globBackend(p.be(), p.path, pattern)
 	if err != nil {
 		return nil
 	}
-	paths := make([]Path, 0, len(files))
-	for _, f := range files{
-		paths = append(paths, *New(f))
+	paths := make([]Path, 0, len(matches))
+	for _, m := range matches {
+		paths = append(paths, *p.derive(m))
 	}
 	return paths
 }
@@ -243,26 +265,28 @@ func (p *Path) Glob(pattern string) []Path  {
 // Find all files matching regex pattern in the path and invokes handler
 func (p *Path) Find(regex string, handler func(Path)) bool {
 	rxp, err := regexp.Compile(regex)
-	if err != nil{
+	if err != nil {
 		return false
 	}
 	files := p.ReadDir()
-	dir := p.String()
-	for _, f := range files{
+	for _, f := range files {
 		fn := f.Name()
-		if  rxp.MatchString(fn){
-			handler(*Join(dir, fn))
+		if rxp.MatchString(fn) {
+			handler(*p.Join(fn))
 		}
 	}
 	return len(files) > 0
 }
 
-func (p *Path) Match(pattern string) bool  {
-	match, err := path.Match(pattern, p.String())
+func (p *Path) Match(pattern string) bool {
+	match, err := path.Match(pattern, p.path)
 	return err == nil && match
 }
 
-func (p *Path) DownloadFrom(url string) error {
+// DownloadFrom fetches url into p. If p already exists it is left untouched.
+// If checksum is provided, the hash is computed while streaming to disk (via
+// io.TeeReader); on mismatch the output is deleted and an error returned.
+func (p *Path) DownloadFrom(url string, checksum ...Checksum) error {
 	if p.Exists() {
 		return nil
 	}
@@ -272,57 +296,88 @@ func (p *Path) DownloadFrom(url string) error {
 	}
 	defer resp.Body.Close()
 
-	out, err := os.Create(p.String())
+	out, err := p.be().Create(p.path)
 	if err != nil {
 		return err
 	}
-	defer out.Close()
-	_, err = io.Copy(out, resp.Body)
-	return err
-}
 
+	var body io.Reader = resp.Body
+	var h hash.Hash
+	if len(checksum) > 0 {
+		h, err = newHash(checksum[0].Algo)
+		if err != nil {
+			out.Close()
+			return err
+		}
+		body = io.TeeReader(resp.Body, h)
+	}
 
+	_, err = io.Copy(out, body)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		p.Remove()
+		return err
+	}
+
+	if h != nil {
+		if got := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(got, checksum[0].Want) {
+			p.Remove()
+			return fmt.Errorf("fpath: checksum mismatch for %s: got %s want %s", url, got, checksum[0].Want)
+		}
+	}
+	return nil
+}
 
 type OpenFlag uint32
 
 const (
-	ForReading = OpenFlag(os.O_RDONLY)
-	ForWriting = OpenFlag(os.O_WRONLY | os.O_CREATE)
+	ForReading   = OpenFlag(os.O_RDONLY)
+	ForWriting   = OpenFlag(os.O_WRONLY | os.O_CREATE)
 	ForReadWrite = OpenFlag(os.O_RDWR | os.O_CREATE)
 	ForAppending = OpenFlag(os.O_RDWR | os.O_CREATE | os.O_APPEND)
-	ForNewWrite = OpenFlag(os.O_WRONLY | os.O_CREATE | os.O_TRUNC)
+	ForNewWrite  = OpenFlag(os.O_WRONLY | os.O_CREATE | os.O_TRUNC)
 )
 
-
-// ReadBytes reads the contents of file as bytes
-func (p *Path) Open(mode OpenFlag) (*os.File, error)  {
-	return os.OpenFile(p.String(), int(mode), 0644)
+// Open opens the file through the path's backend
+func (p *Path) Open(mode OpenFlag) (File, error) {
+	return p.be().Open(p.path, int(mode), 0644)
 }
 
 // ReadBytes reads the contents of file as bytes
-func (p *Path) ReadBytes() []byte  {
-	if content, err := os.ReadFile(p.String()); err == nil{
-		return content
+func (p *Path) ReadBytes() []byte {
+	f, err := p.be().Open(p.path, int(ForReading), 0644)
+	if err != nil {
+		return nil
 	}
-	return nil
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return nil
+	}
+	return content
 }
 
 // WriteBytes writes the contents to a file as bytes
-func (p *Path) WriteBytes(content []byte) error  {
-	return os.WriteFile(p.String(), content, 0644)
+func (p *Path) WriteBytes(content []byte) error {
+	f, err := p.be().Open(p.path, int(ForNewWrite), 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(content)
+	return err
 }
 
 // ReadText reads the contents of file as string
-func (p *Path) ReadText() string  {
-	if content, err := os.ReadFile(p.String()); err == nil{
-		return string(content)
-	}
-	return ""
+func (p *Path) ReadText() string {
+	return string(p.ReadBytes())
 }
 
 // WriteBytes writes the contents to a file as bytes
-func (p *Path) WriteText(content string) error  {
-	return os.WriteFile(p.String(), []byte(content), 0644)
+func (p *Path) WriteText(content string) error {
+	return p.WriteBytes([]byte(content))
 }
 
 // ReadJSON reads the JSON content fom file
@@ -340,22 +395,21 @@ func (p *Path) ReadJsonMap() *map[string]interface{} {
 }
 
 // WriteJSON writes the contents to a file as JSON
-func (p *Path) WriteJson(content interface{}) error  {
-	jsonBytes, err := json.Marshal(content);
+func (p *Path) WriteJson(content interface{}) error {
+	jsonBytes, err := json.Marshal(content)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(p.String(), jsonBytes, 0644)
+	return p.WriteBytes(jsonBytes)
 }
 
-func (p *Path) ReadKV(sep string) ValueMap  {
-	if fd, err := p.Open(ForReading); err == nil{
+func (p *Path) ReadKV(sep string) ValueMap {
+	if fd, err := p.Open(ForReading); err == nil {
 		return LoadValueMap(fd, sep, false, false, false)
 	}
 	return ValueMap{}
 }
 
-
 type Value string
 
 func (v *Value) String() string {
@@ -363,17 +417,17 @@ func (v *Value) String() string {
 }
 
 func (v *Value) Int() int {
-	val, _ := strconv.Atoi(v.String());
+	val, _ := strconv.Atoi(v.String())
 	return val
 }
 
 func (v *Value) Float() float32 {
-	val, _ := strconv.ParseFloat(v.String(), 32);
+	val, _ := strconv.ParseFloat(v.String(), 32)
 	return float32(val)
 }
 
 func (v *Value) Bool() bool {
-	val, _ := strconv.ParseBool(v.String());
+	val, _ := strconv.ParseBool(v.String())
 	return val
 }
 
@@ -385,22 +439,22 @@ func (v *Value) Path() *Path {
 func (v *Value) Array(sep string) []Value {
 	s := v.String()
 	values := []Value{}
-	i := strings.Index(s, sep);
+	i := strings.Index(s, sep)
 	for {
-		if i == -1  && len(s) > 0{
+		if i == -1 && len(s) > 0 {
 			values = append(values, Value(s))
 			break
 		}
 		values = append(values, Value(s[:i]))
-		s = s[i + len(sep):]
-		i = strings.Index(s, sep);
+		s = s[i+len(sep):]
+		i = strings.Index(s, sep)
 	}
 	return values
 }
 
-type ValueMap map[string] *Value
+type ValueMap map[string]*Value
 
-func LoadValueMap(fd io.Reader, sep string, unquote, expandVars, setEnv bool)  ValueMap{
+func LoadValueMap(fd io.Reader, sep string, unquote, expandVars, setEnv bool) ValueMap {
 	kvmap := make(ValueMap)
 	scanner := bufio.NewScanner(fd)
 	for scanner.Scan() {
@@ -413,7 +467,7 @@ func LoadValueMap(fd io.Reader, sep string, unquote, expandVars, setEnv bool)  V
 
 		// Skip lines without separator or no values
 		isep := strings.Index(line, sep)
-		if isep < 0 || len(line) < isep + 1{
+		if isep < 0 || len(line) < isep+1 {
 			continue
 		}
 
@@ -448,7 +502,6 @@ func LoadValueMap(fd io.Reader, sep string, unquote, expandVars, setEnv bool)  V
 	return kvmap
 }
 
-
 // PrettySize formats size to IEC units
 func PrettySize(b int64) string {
 	const unit = 1024
@@ -463,6 +516,3 @@ func PrettySize(b int64) string {
 	return fmt.Sprintf("%.1f %cB",
 		float64(b)/float64(div), "KMGTPE"[exp])
 }
-
-
-
@@ -15,9 +15,9 @@ func TestJoin(t *testing.T) {
 		args args
 		want Path
 	}{
-		{"empty", args{[]string{"", ""}}, Path("")},
-		{"/", args{[]string{"", "/"}}, Path("/")},
-		{"../a/b", args{[]string{"..", "a", "b"}}, Path("../a/b")},
+		{"empty", args{[]string{"", ""}}, Path{path: ""}},
+		{"/", args{[]string{"", "/"}}, Path{path: "/"}},
+		{"../a/b", args{[]string{"..", "a", "b"}}, Path{path: "../a/b"}},
 
 	}
 	for _, tt := range tests {
@@ -38,7 +38,7 @@ func TestNew(t *testing.T) {
 		args args
 		want Path
 	}{
-		{"simple", args{"a/b"}, Path("a/b")},
+		{"simple", args{"a/b"}, Path{path: "a/b"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -59,8 +59,8 @@ func TestPath_Join(t *testing.T) {
 		args args
 		want Path
 	}{
-		{"empty", Path(""), args{[]string{"", ""}}, Path("")},
-		{"a/b/c", Path("a"), args{[]string{"b", "c"}}, Path("a/b/c")},
+		{"empty", Path{path: ""}, args{[]string{"", ""}}, Path{path: ""}},
+		{"a/b/c", Path{path: "a"}, args{[]string{"b", "c"}}, Path{path: "a/b/c"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -77,8 +77,8 @@ func TestPath_Abs(t *testing.T) {
 		p    Path
 		want Path
 	}{
-		{"/a/b/c", Path("/a/../../x"), Path("/x")},
-		{"Cwd/a/b/c", Path("a/../x"), *Cwd().Join("/x")},
+		{"/a/b/c", Path{path: "/a/../../x"}, Path{path: "/x"}},
+		{"Cwd/a/b/c", Path{path: "a/../x"}, *Cwd().Join("/x")},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -99,10 +99,10 @@ func TestPath_Parents(t *testing.T) {
 		args args
 		want Path
 	}{
-		{":empty", Path(""), args{ 0}, Path(".")},
-		{":/", Path("/"), args{ 0}, Path("/")},
-		{":/a", Path("/a"), args{ 0}, Path("/")},
-		{":/a/b", Path("/a/b"), args{ 1}, Path("/")},
+		{":empty", Path{path: ""}, args{0}, Path{path: "."}},
+		{":/", Path{path: "/"}, args{0}, Path{path: "/"}},
+		{":/a", Path{path: "/a"}, args{0}, Path{path: "/"}},
+		{":/a/b", Path{path: "/a/b"}, args{1}, Path{path: "/"}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,59 @@
+package fpath
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// globBackend resolves pattern (filepath.Glob-style, one "*"/"?"/"[...]" per
+// segment, no "**") against dir using backend, so Path.Glob works the same
+// way against any Backend, not just the OS filesystem.
+func globBackend(be Backend, dir, pattern string) ([]string, error) {
+	return globSegments(be, dir, strings.Split(pattern, "/"))
+}
+
+func globSegments(be Backend, dir string, segments []string) ([]string, error) {
+	if len(segments) == 0 {
+		return []string{dir}, nil
+	}
+	seg, rest := segments[0], segments[1:]
+
+	if !strings.ContainsAny(seg, "*?[") {
+		next := path.Join(dir, seg)
+		if len(rest) == 0 {
+			if _, err := be.Stat(next); err != nil {
+				return nil, nil
+			}
+			return []string{next}, nil
+		}
+		return globSegments(be, next, rest)
+	}
+
+	entries, err := be.ReadDir(dir)
+	if err != nil {
+		return nil, nil
+	}
+	var matches []string
+	for _, e := range entries {
+		ok, err := path.Match(seg, e.Name())
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		next := path.Join(dir, e.Name())
+		if len(rest) == 0 {
+			matches = append(matches, next)
+			continue
+		}
+		sub, err := globSegments(be, next, rest)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, sub...)
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
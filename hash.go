@@ -0,0 +1,83 @@
+package fpath
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+	"strings"
+)
+
+// HashAlgo selects the hash function used by Path.Hash.
+type HashAlgo int
+
+const (
+	MD5 HashAlgo = iota
+	SHA1
+	SHA256
+	SHA512
+	CRC32
+)
+
+func newHash(algo HashAlgo) (hash.Hash, error) {
+	switch algo {
+	case MD5:
+		return md5.New(), nil
+	case SHA1:
+		return sha1.New(), nil
+	case SHA256:
+		return sha256.New(), nil
+	case SHA512:
+		return sha512.New(), nil
+	case CRC32:
+		return crc32.NewIEEE(), nil
+	default:
+		return nil, fmt.Errorf("fpath: unsupported hash algorithm %d", algo)
+	}
+}
+
+// Hash streams the file through algo in 64 KiB chunks, rather than reading it
+// whole as ReadBytes does, and returns the digest.
+func (p *Path) Hash(algo HashAlgo) ([]byte, error) {
+	f, err := p.be().Open(p.path, int(ForReading), 0644)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	h, err := newHash(algo)
+	if err != nil {
+		return nil, err
+	}
+	// Hide any WriterTo on f (e.g. *os.File) so CopyBuffer uses this buffer.
+	if _, err := io.CopyBuffer(h, struct{ io.Reader }{f}, make([]byte, 64*1024)); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// HashHex is Hash formatted as a lowercase hex string.
+func (p *Path) HashHex(algo HashAlgo) (string, error) {
+	sum, err := p.Hash(algo)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum), nil
+}
+
+// HashMatches reports whether p's content hashes to want under algo.
+func (p *Path) HashMatches(algo HashAlgo, want string) bool {
+	got, err := p.HashHex(algo)
+	return err == nil && strings.EqualFold(got, want)
+}
+
+// Checksum is an expected content hash, verified by DownloadFrom (and FromUrl)
+// while it streams a download to disk.
+type Checksum struct {
+	Algo HashAlgo
+	Want string
+}
@@ -0,0 +1,70 @@
+package fpath
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPath_Hash(t *testing.T) {
+	be := NewMemBackend()
+	p := New("/file.txt").WithBackend(be)
+	if err := p.WriteBytes([]byte("hello world")); err != nil {
+		t.Fatalf("WriteBytes() error = %v", err)
+	}
+
+	sum := sha256.Sum256([]byte("hello world"))
+	want := hex.EncodeToString(sum[:])
+
+	got, err := p.HashHex(SHA256)
+	if err != nil {
+		t.Fatalf("HashHex() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("HashHex() = %s, want %s", got, want)
+	}
+	if !p.HashMatches(SHA256, want) {
+		t.Error("HashMatches() = false for the correct hash, want true")
+	}
+	if p.HashMatches(SHA256, strings.Repeat("0", 64)) {
+		t.Error("HashMatches() = true for a wrong hash, want false")
+	}
+}
+
+func TestDownloadFrom_ChecksumMismatch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("actual content"))
+	}))
+	defer srv.Close()
+
+	p := New(t.TempDir() + "/download.bin")
+	err := p.DownloadFrom(srv.URL, Checksum{Algo: SHA256, Want: strings.Repeat("0", 64)})
+	if err == nil {
+		t.Fatal("DownloadFrom() with a mismatching checksum succeeded, want error")
+	}
+	if p.Exists() {
+		t.Error("DownloadFrom() left the mismatched file on disk")
+	}
+}
+
+func TestDownloadFrom_ChecksumMatch(t *testing.T) {
+	content := []byte("matching content")
+	sum := sha256.Sum256(content)
+	want := hex.EncodeToString(sum[:])
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer srv.Close()
+
+	p := New(t.TempDir() + "/download.bin")
+	if err := p.DownloadFrom(srv.URL, Checksum{Algo: SHA256, Want: want}); err != nil {
+		t.Fatalf("DownloadFrom() error = %v", err)
+	}
+	if got := p.ReadText(); got != string(content) {
+		t.Errorf("downloaded content = %q, want %q", got, string(content))
+	}
+}
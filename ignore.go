@@ -0,0 +1,28 @@
+package fpath
+
+import (
+	"fmt"
+	"strings"
+)
+
+// LoadIgnore reads name (a .gitignore-format file, e.g. ".gitignore") from
+// inside p and compiles it into a Matcher, which can be passed to WalkMatch
+// as an exclusion set. Supports negation ("!"), root-anchoring ("/prefix"),
+// directory-only rules ("suffix/"), and "#" comments.
+func (p *Path) LoadIgnore(name string) (Matcher, error) {
+	ignoreFile := p.Join(name)
+	content := ignoreFile.ReadBytes()
+	if content == nil {
+		return nil, fmt.Errorf("fpath: could not read %s", ignoreFile.String())
+	}
+	var patterns []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimRight(line, "\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		patterns = append(patterns, trimmed)
+	}
+	return Patterns(patterns...), nil
+}
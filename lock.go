@@ -0,0 +1,79 @@
+package fpath
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// LockOptions selects the mode of a Path.Lock call.
+type LockOptions struct {
+	// Exclusive requests a write lock; otherwise a shared (read) lock is taken.
+	Exclusive bool
+
+	// Blocking retries with backoff until Timeout elapses instead of failing
+	// immediately when the lock is held elsewhere.
+	Blocking bool
+
+	// Timeout bounds how long a blocking acquisition will retry. Zero means
+	// retry forever.
+	Timeout time.Duration
+}
+
+// Lock is a held advisory lock on a file, acquired via Path.Lock.
+type Lock struct {
+	file      *os.File
+	exclusive bool
+}
+
+// Lock acquires an advisory lock on the file at p (creating it if it does not
+// exist yet) according to opts. The file descriptor is retained until Unlock,
+// so the lock outlives this call.
+//
+// Locking is an OS-level primitive with no meaning against a virtual
+// filesystem, so p's backend must implement PathResolver (OSBackend and
+// BasePathBackend do; MemBackend does not) — otherwise Lock fails explicitly
+// rather than silently opening the real OS path.
+func (p *Path) Lock(opts LockOptions) (*Lock, error) {
+	resolver, ok := p.be().(PathResolver)
+	if !ok {
+		return nil, fmt.Errorf("fpath: backend %T does not support Lock", p.be())
+	}
+	real, err := resolver.ResolvePath(p.path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(real, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if err := acquire(f, opts.Exclusive, opts.Blocking, opts.Timeout); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &Lock{file: f, exclusive: opts.Exclusive}, nil
+}
+
+// Unlock releases the lock and closes the underlying file descriptor.
+func (l *Lock) Unlock() error {
+	defer l.file.Close()
+	return unlock(l.file)
+}
+
+// Upgrade blocks until the lock can be converted to exclusive.
+func (l *Lock) Upgrade() error {
+	if err := changeMode(l.file, true); err != nil {
+		return err
+	}
+	l.exclusive = true
+	return nil
+}
+
+// Downgrade converts the lock to shared.
+func (l *Lock) Downgrade() error {
+	if err := changeMode(l.file, false); err != nil {
+		return err
+	}
+	l.exclusive = false
+	return nil
+}
@@ -0,0 +1,70 @@
+package fpath
+
+import (
+	"testing"
+)
+
+func TestPath_LockCreatesFile(t *testing.T) {
+	p := New(t.TempDir() + "/created.lock")
+	if p.Exists() {
+		t.Fatal("file already exists before Lock()")
+	}
+
+	lock, err := p.Lock(LockOptions{Exclusive: true})
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	defer lock.Unlock()
+
+	if !p.Exists() {
+		t.Error("Lock() did not create the file")
+	}
+}
+
+func TestPath_LockUpgradeDowngrade(t *testing.T) {
+	p := New(t.TempDir() + "/lockfile")
+
+	lock, err := p.Lock(LockOptions{Exclusive: false})
+	if err != nil {
+		t.Fatalf("Lock(shared) error = %v", err)
+	}
+	if err := lock.Upgrade(); err != nil {
+		t.Fatalf("Upgrade() error = %v", err)
+	}
+	if !lock.exclusive {
+		t.Error("Upgrade() did not mark the lock exclusive")
+	}
+	if err := lock.Downgrade(); err != nil {
+		t.Fatalf("Downgrade() error = %v", err)
+	}
+	if lock.exclusive {
+		t.Error("Downgrade() did not clear the lock's exclusive flag")
+	}
+	if err := lock.Unlock(); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+}
+
+func TestPath_LockRejectsUnresolvableBackend(t *testing.T) {
+	p := New("/file").WithBackend(NewMemBackend())
+
+	if _, err := p.Lock(LockOptions{}); err == nil {
+		t.Error("Lock() over MemBackend succeeded, want error (MemBackend has no PathResolver)")
+	}
+}
+
+func TestPath_LockThroughBasePathBackend(t *testing.T) {
+	root := t.TempDir()
+	be := NewBasePathBackend(root, NewOSBackend())
+	p := New("/escape.lock").WithBackend(be)
+
+	lock, err := p.Lock(LockOptions{Exclusive: true})
+	if err != nil {
+		t.Fatalf("Lock() error = %v", err)
+	}
+	defer lock.Unlock()
+
+	if !New(root).Join("escape.lock").Exists() {
+		t.Error("Lock() did not create the file inside the confined root")
+	}
+}
@@ -0,0 +1,60 @@
+//go:build !windows
+
+package fpath
+
+import (
+	"io"
+	"os"
+	"syscall"
+	"time"
+)
+
+func acquire(f *os.File, exclusive, blocking bool, timeout time.Duration) error {
+	lockType := int16(syscall.F_RDLCK)
+	if exclusive {
+		lockType = syscall.F_WRLCK
+	}
+	flock := syscall.Flock_t{
+		Type:   lockType,
+		Whence: io.SeekStart,
+		Start:  0,
+		Len:    0,
+		Pid:    int32(os.Getpid()),
+	}
+	if !blocking {
+		return syscall.FcntlFlock(f.Fd(), syscall.F_SETLK, &flock)
+	}
+
+	deadline := time.Now().Add(timeout)
+	backoff := 10 * time.Millisecond
+	for {
+		err := syscall.FcntlFlock(f.Fd(), syscall.F_SETLK, &flock)
+		if err == nil {
+			return nil
+		}
+		if timeout > 0 && time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(backoff)
+		if backoff < 500*time.Millisecond {
+			backoff *= 2
+		}
+	}
+}
+
+// changeMode converts the lock type in place: POSIX fcntl(F_SETLK) allows
+// re-locking an already-held range with a different type directly.
+func changeMode(f *os.File, exclusive bool) error {
+	return acquire(f, exclusive, true, 0)
+}
+
+func unlock(f *os.File) error {
+	flock := syscall.Flock_t{
+		Type:   syscall.F_UNLCK,
+		Whence: io.SeekStart,
+		Start:  0,
+		Len:    0,
+		Pid:    int32(os.Getpid()),
+	}
+	return syscall.FcntlFlock(f.Fd(), syscall.F_SETLK, &flock)
+}
@@ -0,0 +1,62 @@
+//go:build windows
+
+package fpath
+
+import (
+	"os"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockRangeLen locks/unlocks the whole file; since Lock always requests and
+// releases the same range, there is no partial-range bookkeeping to do.
+const lockRangeLen = ^uint32(0)
+
+func acquire(f *os.File, exclusive, blocking bool, timeout time.Duration) error {
+	handle := windows.Handle(f.Fd())
+	// Always fail immediately: blocking mode retries below so Timeout applies.
+	flags := uint32(windows.LOCKFILE_FAIL_IMMEDIATELY)
+	if exclusive {
+		flags |= windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+
+	tryLock := func() error {
+		return windows.LockFileEx(handle, flags, 0, lockRangeLen, lockRangeLen, new(windows.Overlapped))
+	}
+
+	if !blocking {
+		return tryLock()
+	}
+
+	deadline := time.Now().Add(timeout)
+	backoff := 10 * time.Millisecond
+	for {
+		err := tryLock()
+		if err == nil {
+			return nil
+		}
+		if timeout > 0 && time.Now().After(deadline) {
+			return err
+		}
+		time.Sleep(backoff)
+		if backoff < 500*time.Millisecond {
+			backoff *= 2
+		}
+	}
+}
+
+func unlock(f *os.File) error {
+	handle := windows.Handle(f.Fd())
+	return windows.UnlockFileEx(handle, 0, lockRangeLen, lockRangeLen, new(windows.Overlapped))
+}
+
+// changeMode releases the range held on f and re-acquires it in the new
+// mode. Unlike fcntl(F_SETLK) on Unix, LockFileEx cannot convert a held lock
+// in place: re-locking an already-held range just fails.
+func changeMode(f *os.File, exclusive bool) error {
+	if err := unlock(f); err != nil {
+		return err
+	}
+	return acquire(f, exclusive, true, 0)
+}
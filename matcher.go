@@ -0,0 +1,134 @@
+package fpath
+
+import (
+	"path"
+	"strings"
+)
+
+// Matcher decides whether a path, given relative to some walk root, matches
+// a set of rules. Implementations are returned by Patterns and LoadIgnore and
+// are consumed by WalkMatch/WalkMatchFunc.
+type Matcher interface {
+	Match(relPath string, isDir bool) bool
+}
+
+type matchRule struct {
+	pattern  string
+	negate   bool
+	anchored bool
+	dirOnly  bool
+}
+
+type patternMatcher struct {
+	rules []matchRule
+}
+
+// Patterns compiles doublestar glob patterns into a Matcher. Supported syntax:
+// "**" matches any number of path segments (including zero), "*" matches
+// within a single segment, "?" a single char, "[abc]" character classes, and
+// "{a,b}" alternation. A pattern prefixed with "!" inverts the match. A
+// leading "/" anchors the pattern to the walk root; a trailing "/" matches
+// directories only. Rules are evaluated in order, later rules overriding
+// earlier ones, matching git's semantics.
+func Patterns(patterns ...string) Matcher {
+	pm := &patternMatcher{rules: make([]matchRule, 0, len(patterns))}
+	for _, raw := range patterns {
+		if raw == "" {
+			continue
+		}
+		pm.rules = append(pm.rules, compileRule(raw))
+	}
+	return pm
+}
+
+func compileRule(raw string) matchRule {
+	negate := strings.HasPrefix(raw, "!")
+	if negate {
+		raw = raw[1:]
+	}
+	anchored := strings.HasPrefix(raw, "/")
+	if anchored {
+		raw = raw[1:]
+	}
+	dirOnly := strings.HasSuffix(raw, "/")
+	if dirOnly {
+		raw = raw[:len(raw)-1]
+	}
+	return matchRule{pattern: raw, negate: negate, anchored: anchored, dirOnly: dirOnly}
+}
+
+func (pm *patternMatcher) Match(relPath string, isDir bool) bool {
+	relPath = strings.TrimPrefix(relPath, "/")
+	matched := false
+	for _, r := range pm.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		if doublestarMatch(r.pattern, relPath) || (!r.anchored && doublestarMatch("**/"+r.pattern, relPath)) {
+			matched = !r.negate
+		}
+	}
+	return matched
+}
+
+// doublestarMatch reports whether name matches pattern, where pattern may
+// contain "**" path-spanning wildcards in addition to the usual path.Match
+// syntax and "{a,b}" alternation.
+func doublestarMatch(pattern, name string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchSegments(pat, name []string) bool {
+	if len(pat) == 0 {
+		return len(name) == 0
+	}
+	if pat[0] == "**" {
+		if len(pat) == 1 {
+			return true
+		}
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pat[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if !matchSegment(pat[0], name[0]) {
+		return false
+	}
+	return matchSegments(pat[1:], name[1:])
+}
+
+func matchSegment(pat, name string) bool {
+	for _, alt := range expandBraces(pat) {
+		if ok, err := path.Match(alt, name); ok && err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// expandBraces expands one level of "{a,b,c}" alternation (recursively, so
+// multiple groups in the same segment are all expanded).
+func expandBraces(pattern string) []string {
+	start := strings.IndexByte(pattern, '{')
+	if start < 0 {
+		return []string{pattern}
+	}
+	rel := strings.IndexByte(pattern[start:], '}')
+	if rel < 0 {
+		return []string{pattern}
+	}
+	end := start + rel
+	prefix, alts, suffix := pattern[:start], pattern[start+1:end], pattern[end+1:]
+	var out []string
+	for _, a := range strings.Split(alts, ",") {
+		for _, tail := range expandBraces(suffix) {
+			out = append(out, prefix+a+tail)
+		}
+	}
+	return out
+}
@@ -0,0 +1,81 @@
+package fpath
+
+import "fmt"
+
+// SyncOptions configures Path.SyncTo.
+type SyncOptions struct {
+	// Delete removes files in dst that no longer exist in the source tree.
+	Delete bool
+
+	// SkipUnchanged skips copying files that already match in dst, compared
+	// by size+mtime, or by content hash if CompareHash is set.
+	SkipUnchanged bool
+	CompareHash   bool
+	Algo          HashAlgo
+
+	Copy CopyOptions
+}
+
+// SyncTo mirrors p's tree into dst, creating directories as needed.
+func (p *Path) SyncTo(dst *Path, opts SyncOptions) error {
+	if !p.IsDir() {
+		return fmt.Errorf("fpath: %s is not a directory", p.String())
+	}
+	if !dst.Exists() {
+		if err := dst.MkDir(0755, true); err != nil {
+			return err
+		}
+	} else if !dst.IsDir() {
+		return fmt.Errorf("fpath: %s is not a directory", dst.String())
+	}
+
+	seen := map[string]bool{}
+	for _, child := range p.ListDir(true) {
+		name := child.Base()
+		dstChild := dst.Join(name)
+		seen[name] = true
+
+		if child.IsDir() {
+			if err := child.SyncTo(dstChild, opts); err != nil {
+				return err
+			}
+			continue
+		}
+		if opts.SkipUnchanged && filesMatch(&child, dstChild, opts) {
+			continue
+		}
+		copyOpts := opts.Copy
+		copyOpts.Overwrite = true
+		if _, err := child.CopyTo(dstChild, copyOpts); err != nil {
+			return err
+		}
+	}
+
+	if opts.Delete {
+		for _, existing := range dst.ListDir(true) {
+			if seen[existing.Base()] {
+				continue
+			}
+			if err := existing.RemoveAll(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func filesMatch(src, dst *Path, opts SyncOptions) bool {
+	if !dst.Exists() {
+		return false
+	}
+	if opts.CompareHash {
+		sh, err := src.HashHex(opts.Algo)
+		if err != nil {
+			return false
+		}
+		dh, err := dst.HashHex(opts.Algo)
+		return err == nil && sh == dh
+	}
+	s, d := src.Stat(), dst.Stat()
+	return s != nil && d != nil && s.Size() == d.Size() && s.ModTime().Equal(d.ModTime())
+}
@@ -0,0 +1,52 @@
+package fpath
+
+import (
+	"path/filepath"
+)
+
+// WalkMatch walks the tree rooted at p and returns every path matching
+// include (doublestar patterns; the whole tree matches if include is empty)
+// that exclude does not match. A directory that exclude matches is not
+// descended into, so nothing beneath it is returned. exclude may be nil.
+func (p *Path) WalkMatch(include []string, exclude Matcher) []Path {
+	var out []Path
+	p.WalkMatchFunc(include, exclude, func(child Path) bool {
+		out = append(out, child)
+		return true
+	})
+	return out
+}
+
+// WalkMatchFunc is the streaming form of WalkMatch: fn is called for every
+// matching path until it returns false.
+func (p *Path) WalkMatchFunc(include []string, exclude Matcher, fn func(Path) bool) {
+	inc := Patterns(include...)
+	root := p.path
+	stop := false
+	var walk func(dir *Path)
+	walk = func(dir *Path) {
+		for _, child := range dir.ListDir(true) {
+			if stop {
+				return
+			}
+			child := child
+			rel, err := filepath.Rel(root, child.String())
+			if err != nil {
+				rel = child.String()
+			}
+			isDir := child.IsDir()
+			included := len(include) == 0 || inc.Match(rel, isDir)
+			excluded := exclude != nil && exclude.Match(rel, isDir)
+			if included && !excluded {
+				if !fn(child) {
+					stop = true
+					return
+				}
+			}
+			if isDir && !excluded {
+				walk(&child)
+			}
+		}
+	}
+	walk(p)
+}
@@ -0,0 +1,97 @@
+package fpath
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func buildMemTree(t *testing.T) *Path {
+	t.Helper()
+	be := NewMemBackend()
+	root := New("/root").WithBackend(be)
+	for _, f := range []string{"a.go", "b.txt", "sub/c.go", "sub/d.txt", "sub/deep/e.go"} {
+		fp := root.Join(f)
+		if err := fp.Parent().MkDir(0755, true); err != nil {
+			t.Fatalf("MkDir(%s) error = %v", fp.Parent(), err)
+		}
+		if err := fp.WriteBytes([]byte("x")); err != nil {
+			t.Fatalf("WriteBytes(%s) error = %v", fp, err)
+		}
+	}
+	return root
+}
+
+func relNames(root *Path, paths []Path) []string {
+	prefix := root.String() + "/"
+	names := make([]string, len(paths))
+	for i, p := range paths {
+		names[i] = strings.TrimPrefix(p.String(), prefix)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestWalkMatch_Include(t *testing.T) {
+	root := buildMemTree(t)
+
+	got := relNames(root, root.WalkMatch([]string{"**/*.go"}, nil))
+	want := []string{"a.go", "sub/c.go", "sub/deep/e.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkMatch(**/*.go) = %v, want %v", got, want)
+	}
+}
+
+func TestWalkMatch_Exclude(t *testing.T) {
+	root := buildMemTree(t)
+
+	got := relNames(root, root.WalkMatch(nil, Patterns("sub/**")))
+	want := []string{"a.go", "b.txt"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkMatch exclude sub/** = %v, want %v", got, want)
+	}
+}
+
+func TestPatterns_Negate(t *testing.T) {
+	m := Patterns("*.go", "!keep.go")
+	if !m.Match("a.go", false) {
+		t.Error("Match(a.go) = false, want true")
+	}
+	if m.Match("keep.go", false) {
+		t.Error("Match(keep.go) = true, want false (negated by !keep.go)")
+	}
+}
+
+func TestLoadIgnore(t *testing.T) {
+	be := NewMemBackend()
+	root := New("/root").WithBackend(be)
+	if err := root.MkDir(0755, true); err != nil {
+		t.Fatalf("MkDir() error = %v", err)
+	}
+	if err := root.Join(".gitignore").WriteText("*.log\n!keep.log\n/build/\n"); err != nil {
+		t.Fatalf("WriteText() error = %v", err)
+	}
+
+	m, err := root.LoadIgnore(".gitignore")
+	if err != nil {
+		t.Fatalf("LoadIgnore() error = %v", err)
+	}
+
+	cases := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"debug.log", false, true},
+		{"keep.log", false, false},
+		{"build", true, true},
+		{"build", false, false},
+		{"src/main.go", false, false},
+	}
+	for _, c := range cases {
+		if got := m.Match(c.rel, c.isDir); got != c.want {
+			t.Errorf("Match(%q, dir=%v) = %v, want %v", c.rel, c.isDir, got, c.want)
+		}
+	}
+}
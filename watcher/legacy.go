@@ -0,0 +1,41 @@
+package watcher
+
+import (
+	"log"
+	"path"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/naharp/fpath"
+)
+
+// Handler function is called when file changes. Return to true to chain to next matching handler
+type Handler func(action string, target fpath.Path) bool
+
+type EventMap map[string]Handler
+
+// WatchMap is a thin adapter over the pre-WatchConfig API: callers register
+// glob pattern handlers keyed by basename and are expected to Add paths on
+// the returned *fsnotify.Watcher themselves. Internally it runs on the same
+// Watcher/WatchConfig machinery as Watch, just dispatching each event to the
+// matching EventMap handlers instead of an OnBatch callback.
+func WatchMap(e EventMap) *fsnotify.Watcher {
+	w, err := Watch(WatchConfig{
+		OnBatch: func(events []Event) {
+			for _, ev := range events {
+				base := path.Base(ev.Path.String())
+				for pattern, handler := range e {
+					match, err := path.Match(pattern, base)
+					if match && err == nil {
+						if !handler(ev.Op.String(), ev.Path) {
+							break
+						}
+					}
+				}
+			}
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	return w.fs
+}
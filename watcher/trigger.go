@@ -0,0 +1,69 @@
+package watcher
+
+import (
+	"log"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// TriggerConfig runs a shell command whenever matching files change, signalling
+// a still-running prior invocation before restarting it. Useful for
+// build-on-save workflows.
+type TriggerConfig struct {
+	WatchConfig
+
+	// Command is split on whitespace and executed with exec.Command.
+	Command string
+
+	// Signal is sent to a still-running prior invocation before it is
+	// restarted. Defaults to os.Interrupt.
+	Signal os.Signal
+}
+
+// RunTrigger starts watching cfg.Paths and (re)runs cfg.Command on every
+// matching batch of changes, stopping any previous run first.
+func RunTrigger(cfg TriggerConfig) (*Watcher, error) {
+	var mu sync.Mutex
+	var cmd *exec.Cmd
+
+	restart := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		if cmd != nil && cmd.Process != nil {
+			sig := cfg.Signal
+			if sig == nil {
+				sig = os.Interrupt
+			}
+			if err := cmd.Process.Signal(sig); err != nil {
+				log.Println(err)
+			}
+			cmd.Wait()
+		}
+		args := strings.Fields(cfg.Command)
+		if len(args) == 0 {
+			return
+		}
+		next := exec.Command(args[0], args[1:]...)
+		next.Stdout = os.Stdout
+		next.Stderr = os.Stderr
+		if err := next.Start(); err != nil {
+			log.Println(err)
+			return
+		}
+		cmd = next
+	}
+
+	inner := cfg.WatchConfig
+	onBatch := inner.OnBatch
+	inner.OnBatch = func(events []Event) {
+		if onBatch != nil {
+			onBatch(events)
+		}
+		restart()
+	}
+
+	restart()
+	return Watch(inner)
+}
@@ -1,63 +1,187 @@
 package watcher
 
 import (
-	"github.com/fsnotify/fsnotify"
-	"github.com/naharp/fpath"
 	"log"
 	"path"
+	"sync"
 	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/naharp/fpath"
 )
 
+// Event describes a single filesystem change delivered to WatchConfig.OnBatch.
+type Event struct {
+	Path fpath.Path
+	Op   fsnotify.Op
+	Time time.Time
+}
+
+// WatchConfig configures a recursive, debounced watch over a set of paths.
+type WatchConfig struct {
+	Paths []fpath.Path
 
-// Handler function is called when file changes. Return to true to chain to next matching handler
-type Handler func(action string, target fpath.Path) bool
+	// IncludeGlobs/ExcludeGlobs match against the basename of changed files.
+	// A file must match at least one IncludeGlobs entry (or IncludeGlobs must
+	// be empty) and no ExcludeGlobs entry to be reported.
+	IncludeGlobs []string
+	ExcludeGlobs []string
 
-type EventMap map[string] Handler
+	// Depth limits how many directory levels below each root are
+	// auto-subscribed when Recursive is set. Depth<0 means infinite.
+	Depth int
+
+	// Debounce coalesces a burst of events for the same path into a single
+	// OnBatch call, delivered once Debounce has elapsed with no further
+	// changes to that path. Zero disables debouncing.
+	Debounce time.Duration
+
+	// Recursive walks each root (via Path.ListDir) and subscribes to every
+	// subdirectory, adding newly created subdirectories as they appear.
+	Recursive bool
+
+	OnBatch func(events []Event)
+}
+
+// Watcher is a running watch started by Watch.
+type Watcher struct {
+	fs    *fsnotify.Watcher
+	cfg   WatchConfig
+	done  chan struct{}
+	mu    sync.Mutex
+	timer map[string]*time.Timer
+	depth map[string]int
+}
 
-func Watch(e EventMap) *fsnotify.Watcher {
-	watcher, err := fsnotify.NewWatcher()
+// Watch starts watching cfg.Paths according to cfg, delivering batches to
+// cfg.OnBatch until the returned Watcher is closed.
+func Watch(cfg WatchConfig) (*Watcher, error) {
+	fsw, err := fsnotify.NewWatcher()
 	if err != nil {
-		log.Fatal(err)
-	}
-	defer watcher.Close()
-	go func() {
-		mtimes := map[string] time.Time{}
-		for {
-			select {
-			case ev := <-watcher.Events:
-				for pattern, handler := range e {
-					match, err := path.Match(pattern, path.Base(ev.Name))
-					if match && err == nil {
-						fp := fpath.Path(ev.Name)
-						stat := fp.Stat()
-						lastMtime, exists := mtimes[ev.Name]
-						if !exists || stat == nil || lastMtime != stat.ModTime() {
-							if stat != nil{
-								mtimes[ev.Name] = stat.ModTime()
-							}
-							if !handler(ev.Op.String(), fp) {
-								break
-							}
-						}
+		return nil, err
+	}
+	w := &Watcher{fs: fsw, cfg: cfg, done: make(chan struct{}), timer: map[string]*time.Timer{}, depth: map[string]int{}}
+	for _, p := range cfg.Paths {
+		if err := w.subscribe(p, 0); err != nil {
+			fsw.Close()
+			return nil, err
+		}
+	}
+	go w.loop()
+	return w, nil
+}
+
+// Close stops the watch and releases the underlying fsnotify watcher.
+func (w *Watcher) Close() error {
+	close(w.done)
+	return w.fs.Close()
+}
+
+// depthOf returns the recorded subscription depth of dir (0 for the configured
+// roots), or 0 if dir was never subscribed.
+func (w *Watcher) depthOf(dir string) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.depth[dir]
+}
+
+func (w *Watcher) subscribe(p fpath.Path, depth int) error {
+	if err := w.fs.Add(p.String()); err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.depth[p.String()] = depth
+	w.mu.Unlock()
+	if !w.cfg.Recursive {
+		return nil
+	}
+	if w.cfg.Depth >= 0 && depth >= w.cfg.Depth {
+		return nil
+	}
+	for _, child := range p.ListDir(true) {
+		if child.IsDir() {
+			if err := w.subscribe(child, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *Watcher) matches(name string) bool {
+	base := path.Base(name)
+	included := len(w.cfg.IncludeGlobs) == 0
+	for _, g := range w.cfg.IncludeGlobs {
+		if ok, _ := path.Match(g, base); ok {
+			included = true
+			break
+		}
+	}
+	if !included {
+		return false
+	}
+	for _, g := range w.cfg.ExcludeGlobs {
+		if ok, _ := path.Match(g, base); ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *Watcher) deliver(e Event) {
+	if w.cfg.OnBatch != nil {
+		w.cfg.OnBatch([]Event{e})
+	}
+}
+
+func (w *Watcher) debounce(e Event) {
+	name := e.Path.String()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if t, ok := w.timer[name]; ok {
+		t.Stop()
+	}
+	w.timer[name] = time.AfterFunc(w.cfg.Debounce, func() {
+		w.mu.Lock()
+		delete(w.timer, name)
+		w.mu.Unlock()
+		w.deliver(e)
+	})
+}
+
+func (w *Watcher) loop() {
+	for {
+		select {
+		case ev, ok := <-w.fs.Events:
+			if !ok {
+				return
+			}
+			fp := *fpath.New(ev.Name)
+			if w.cfg.Recursive && ev.Op&fsnotify.Create != 0 && fp.IsDir() {
+				parentDepth := w.depthOf(fp.Parent().String())
+				newDepth := parentDepth + 1
+				if w.cfg.Depth < 0 || newDepth <= w.cfg.Depth {
+					if err := w.subscribe(fp, newDepth); err != nil {
+						log.Println(err)
 					}
 				}
-			case err := <-watcher.Errors:
-				// Nothing to do with errors
-				log.Println(err)
 			}
+			if !w.matches(ev.Name) {
+				continue
+			}
+			e := Event{Path: fp, Op: ev.Op, Time: time.Now()}
+			if w.cfg.Debounce <= 0 {
+				w.deliver(e)
+				continue
+			}
+			w.debounce(e)
+		case err, ok := <-w.fs.Errors:
+			if !ok {
+				return
+			}
+			log.Println(err)
+		case <-w.done:
+			return
 		}
-	}()
-	return watcher
-}
-
-//
-//func main()  {
-//	p := fpath.Expand("$HOME")
-//	p.Dir()
-//	Watch(p, EventMap{
-//		"*.css": func(action string, file fpath.Path) bool {
-//			log.Println(action, file)
-//			return true
-//		},
-//	})
-//}
+	}
+}
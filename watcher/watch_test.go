@@ -0,0 +1,163 @@
+package watcher
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/naharp/fpath"
+)
+
+func waitUntil(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		if cond() {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+type eventCollector struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (c *eventCollector) onBatch(events []Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = append(c.events, events...)
+}
+
+func (c *eventCollector) count(path string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	n := 0
+	for _, e := range c.events {
+		if e.Path.String() == path {
+			n++
+		}
+	}
+	return n
+}
+
+func TestWatch_DepthLimit(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "a", "b"), 0755); err != nil {
+		t.Fatalf("MkdirAll() error = %v", err)
+	}
+
+	c := &eventCollector{}
+	w, err := Watch(WatchConfig{
+		Paths:     []fpath.Path{*fpath.New(root)},
+		Recursive: true,
+		Depth:     1,
+		OnBatch:   c.onBatch,
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	// root is depth 0 and "a" is depth 1, both auto-subscribed; "a/b" is
+	// depth 2 and must be left out of the initial Depth: 1 subscription.
+	shallow := filepath.Join(root, "a", "shallow.txt")
+	deep := filepath.Join(root, "a", "b", "deep.txt")
+	if err := os.WriteFile(shallow, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile(shallow) error = %v", err)
+	}
+	if err := os.WriteFile(deep, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile(deep) error = %v", err)
+	}
+
+	waitUntil(t, 2*time.Second, func() bool { return c.count(shallow) > 0 })
+	time.Sleep(200 * time.Millisecond)
+	if n := c.count(deep); n != 0 {
+		t.Errorf("got %d events for %s beyond Depth limit, want 0", n, deep)
+	}
+}
+
+func TestWatch_RecursiveSubscribeOnCreateRespectsDepth(t *testing.T) {
+	root := t.TempDir()
+
+	c := &eventCollector{}
+	w, err := Watch(WatchConfig{
+		Paths:     []fpath.Path{*fpath.New(root)},
+		Recursive: true,
+		Depth:     1,
+		OnBatch:   c.onBatch,
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	aDir := filepath.Join(root, "a")
+	if err := os.Mkdir(aDir, 0755); err != nil {
+		t.Fatalf("Mkdir(a) error = %v", err)
+	}
+	waitUntil(t, 2*time.Second, func() bool { return w.depthOf(aDir) == 1 })
+
+	// "b" would be depth 2 under the newly-created "a" (depth 1); it must
+	// not be auto-subscribed despite being discovered via a Create event.
+	bDir := filepath.Join(aDir, "b")
+	if err := os.Mkdir(bDir, 0755); err != nil {
+		t.Fatalf("Mkdir(b) error = %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	shallow := filepath.Join(aDir, "shallow.txt")
+	deep := filepath.Join(bDir, "deep.txt")
+	if err := os.WriteFile(shallow, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile(shallow) error = %v", err)
+	}
+	if err := os.WriteFile(deep, []byte("x"), 0644); err != nil {
+		t.Fatalf("WriteFile(deep) error = %v", err)
+	}
+
+	waitUntil(t, 2*time.Second, func() bool { return c.count(shallow) > 0 })
+	time.Sleep(200 * time.Millisecond)
+	if n := c.count(deep); n != 0 {
+		t.Errorf("got %d events for %s beyond Depth limit (b/ should not have been auto-subscribed), want 0", n, deep)
+	}
+}
+
+func TestWatch_DebounceCoalesces(t *testing.T) {
+	root := t.TempDir()
+	target := filepath.Join(root, "hot.txt")
+	if err := os.WriteFile(target, []byte("v0"), 0644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	c := &eventCollector{}
+	w, err := Watch(WatchConfig{
+		Paths:    []fpath.Path{*fpath.New(root)},
+		Debounce: 200 * time.Millisecond,
+		OnBatch:  c.onBatch,
+	})
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	defer w.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := os.WriteFile(target, []byte(fmt.Sprintf("v%d", i+1)), 0644); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	// Give the debounce timer time to fire once after the burst settles.
+	time.Sleep(500 * time.Millisecond)
+
+	if n := c.count(target); n != 1 {
+		t.Errorf("got %d batches for %s during a debounced burst, want 1", n, target)
+	}
+}